@@ -0,0 +1,247 @@
+// Copyright © 2018 Piquette Capital, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/piquette/qtrn/providers"
+	"github.com/piquette/qtrn/utils"
+	"github.com/piquette/qtrn/utils/lineedit"
+)
+
+func init() {
+	rootCmd.AddCommand(interactiveCmd)
+}
+
+var interactiveCmd = &cobra.Command{
+	Use:   "interactive",
+	Short: "Drop into a single-keystroke watchlist REPL",
+	Long: `Interactive opens a raw-mode command loop over the active watchlist:
+
+  q       quit
+  +  -    add / remove a ticker from the watchlist
+  /       open the line editor to type a symbol or command
+  g       group by gainers/losers
+  p  r    pause / resume auto-refresh
+`,
+	RunE: runInteractive,
+}
+
+// refreshInterval is how often the watchlist redraws while unpaused.
+const refreshInterval = 5 * time.Second
+
+// watchlist is the set of symbols the interactive session is tracking,
+// and the state of the render loop over them. A mutex guards it since
+// both the key-reading loop and the refresh ticker touch it and draw to
+// stdout concurrently.
+type watchlist struct {
+	mu        sync.Mutex
+	symbols   []string
+	paused    bool
+	grouped   bool
+	suspended bool // true while the line editor owns the terminal
+}
+
+func runInteractive(cmd *cobra.Command, args []string) error {
+	provider, err := providers.Select(providerFlag)
+	if err != nil {
+		return err
+	}
+
+	renderer, err := utils.NewRenderer("watchlist", templateFlag)
+	if err != nil {
+		return err
+	}
+
+	fd := int(os.Stdin.Fd())
+
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return fmt.Errorf("interactive: failed to enter raw mode: %w", err)
+	}
+	defer term.Restore(fd, state)
+
+	history, err := lineedit.LoadHistory(256)
+	if err != nil {
+		return err
+	}
+	defer history.Save()
+
+	editor := lineedit.NewEditor(os.Stdin, os.Stdout)
+	editor.History = history
+
+	wl := &watchlist{symbols: args}
+
+	redraw := make(chan struct{}, 1)
+	done := make(chan struct{})
+	defer close(done)
+
+	go wl.refreshLoop(provider, renderer, redraw, done)
+	redraw <- struct{}{}
+
+	buf := make([]byte, 1)
+	for {
+		if _, err := os.Stdin.Read(buf); err != nil {
+			return err
+		}
+
+		switch buf[0] {
+		case 'q':
+			return nil
+		case '+', '-':
+			symbol, err := wl.prompt(editor, utils.Bold("symbol: "))
+			if err != nil {
+				return err
+			}
+			wl.apply(buf[0], symbol)
+			poke(redraw)
+		case '/':
+			line, err := wl.prompt(editor, "> ")
+			if err != nil {
+				return err
+			}
+			wl.apply('+', line)
+			poke(redraw)
+		case 'g':
+			wl.mu.Lock()
+			wl.grouped = !wl.grouped
+			wl.mu.Unlock()
+			poke(redraw)
+		case 'p':
+			wl.mu.Lock()
+			wl.paused = true
+			wl.mu.Unlock()
+		case 'r':
+			wl.mu.Lock()
+			wl.paused = false
+			wl.mu.Unlock()
+			poke(redraw)
+		}
+	}
+}
+
+// poke requests a redraw without blocking if one is already pending.
+func poke(redraw chan<- struct{}) {
+	select {
+	case redraw <- struct{}{}:
+	default:
+	}
+}
+
+// refreshLoop redraws the watchlist whenever redraw fires (an explicit
+// poke, or the ticker below) and the watchlist isn't paused. It exits
+// once done is closed.
+func (wl *watchlist) refreshLoop(provider providers.Provider, renderer *utils.Renderer, redraw <-chan struct{}, done <-chan struct{}) {
+	ticker := time.NewTicker(refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			wl.draw(provider, renderer)
+		case <-redraw:
+			wl.draw(provider, renderer)
+		}
+	}
+}
+
+// prompt suspends background redraws for the duration of an
+// editor.Prompt call, so a ticker firing mid-edit can't clear the
+// screen out from under the user's half-typed line.
+func (wl *watchlist) prompt(editor *lineedit.Editor, label string) (string, error) {
+	wl.mu.Lock()
+	wl.suspended = true
+	wl.mu.Unlock()
+
+	defer func() {
+		wl.mu.Lock()
+		wl.suspended = false
+		wl.mu.Unlock()
+	}()
+
+	return editor.Prompt(label)
+}
+
+// apply adds or removes symbol from the watchlist depending on op.
+func (wl *watchlist) apply(op byte, symbol string) {
+	if symbol == "" {
+		return
+	}
+
+	wl.mu.Lock()
+	defer wl.mu.Unlock()
+
+	switch op {
+	case '+':
+		wl.symbols = append(wl.symbols, symbol)
+	case '-':
+		for i, s := range wl.symbols {
+			if s == symbol {
+				wl.symbols = append(wl.symbols[:i], wl.symbols[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// draw fetches a quote per watched symbol and renders the watchlist
+// template over them, skipping the redraw entirely while paused.
+func (wl *watchlist) draw(provider providers.Provider, renderer *utils.Renderer) {
+	wl.mu.Lock()
+	if wl.paused || wl.suspended {
+		wl.mu.Unlock()
+		return
+	}
+	symbols := append([]string(nil), wl.symbols...)
+	grouped := wl.grouped
+	wl.mu.Unlock()
+
+	quotes := make([]*providers.Quote, 0, len(symbols))
+	for _, s := range symbols {
+		q, err := provider.Quote(s)
+		if err != nil {
+			continue
+		}
+		quotes = append(quotes, q)
+	}
+
+	if grouped {
+		sort.SliceStable(quotes, func(i, j int) bool {
+			return changePct(quotes[i]) > changePct(quotes[j])
+		})
+	}
+
+	fmt.Fprint(os.Stdout, utils.ESC+"[2J"+utils.ESC+"[H")
+	renderer.Render(os.Stdout, quotes)
+}
+
+// changePct is a quote's percent move off its previous close, used to
+// sort the watchlist's gainers-to-losers grouping.
+func changePct(q *providers.Quote) float64 {
+	if q.RegularMarketPreviousClose == 0 {
+		return 0
+	}
+	return (q.RegularMarketPrice - q.RegularMarketPreviousClose) / q.RegularMarketPreviousClose
+}
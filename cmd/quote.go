@@ -0,0 +1,54 @@
+// Copyright © 2018 Piquette Capital, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/piquette/qtrn/providers"
+	"github.com/piquette/qtrn/utils"
+)
+
+func init() {
+	rootCmd.AddCommand(quoteCmd)
+}
+
+var quoteCmd = &cobra.Command{
+	Use:   "quote <symbol>",
+	Short: "Print a single quote",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runQuote,
+}
+
+func runQuote(cmd *cobra.Command, args []string) error {
+	provider, err := providers.Select(providerFlag)
+	if err != nil {
+		return err
+	}
+
+	q, err := provider.Quote(args[0])
+	if err != nil {
+		return err
+	}
+
+	r, err := utils.NewRenderer("quote", templateFlag)
+	if err != nil {
+		return err
+	}
+
+	return r.Render(os.Stdout, q)
+}
@@ -0,0 +1,49 @@
+// Copyright © 2018 Piquette Capital, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cmd holds the qtrn CLI's subcommands.
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var rootCmd = &cobra.Command{
+	Use:   "qtrn",
+	Short: "qtrn is a terminal client for stock, crypto, and options quotes",
+}
+
+// providerFlag overrides config.toml's provider key for this invocation
+// (see providers.Select). Read by quote/watchlist's RunE.
+var providerFlag string
+
+// templateFlag overrides the default quote/watchlist/options template
+// (see utils.NewRenderer). Read by quote/watchlist's RunE.
+var templateFlag string
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&providerFlag, "provider", "", "quote provider to use (yahoo, coingecko, iex); defaults to config.toml's provider key")
+	rootCmd.PersistentFlags().StringVar(&templateFlag, "template", "", "path to a custom output template; defaults to the built-in template")
+}
+
+// Execute runs the root command, exiting the process on error.
+func Execute() {
+	if err := rootCmd.Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
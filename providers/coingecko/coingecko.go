@@ -0,0 +1,186 @@
+// Copyright © 2018 Piquette Capital, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package coingecko implements providers.Provider against the public
+// CoinGecko API, for crypto tickers like BTC-USD.
+package coingecko
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/piquette/qtrn/providers"
+)
+
+func init() {
+	providers.Register("coingecko", func() providers.Provider { return &CoinGecko{} })
+}
+
+const baseURL = "https://api.coingecko.com/api/v3"
+
+// CoinGecko is the CoinGecko backed provider. Symbols are expected in
+// "BASE-QUOTE" form, e.g. "BTC-USD".
+type CoinGecko struct {
+	client *http.Client
+}
+
+func (c *CoinGecko) httpClient() *http.Client {
+	if c.client == nil {
+		c.client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return c.client
+}
+
+// splitSymbol turns "BTC-USD" into CoinGecko's ("bitcoin", "usd") pair.
+func splitSymbol(symbol string) (id, vsCurrency string, err error) {
+	parts := strings.SplitN(symbol, "-", 2)
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("coingecko: expected BASE-QUOTE symbol, got %q", symbol)
+	}
+
+	id, ok := coinIDs[strings.ToUpper(parts[0])]
+	if !ok {
+		return "", "", fmt.Errorf("coingecko: unknown coin %q", parts[0])
+	}
+
+	return id, strings.ToLower(parts[1]), nil
+}
+
+// coinIDs maps common tickers to CoinGecko's internal coin ids.
+var coinIDs = map[string]string{
+	"BTC":  "bitcoin",
+	"ETH":  "ethereum",
+	"DOGE": "dogecoin",
+	"SOL":  "solana",
+	"ADA":  "cardano",
+}
+
+// Quote fetches the current simple price for a crypto pair.
+func (c *CoinGecko) Quote(symbol string) (*providers.Quote, error) {
+	id, vs, err := splitSymbol(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/simple/price?ids=%s&vs_currencies=%s&include_24hr_change=true", baseURL, id, vs)
+	resp, err := c.httpClient().Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var body map[string]map[string]float64
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+
+	prices, ok := body[id]
+	if !ok {
+		return nil, fmt.Errorf("coingecko: no price data for %q", symbol)
+	}
+
+	price := prices[vs]
+	changePct := prices[vs+"_24h_change"]
+	prevClose := price
+	if changePct != -100 {
+		prevClose = price / (1 + changePct/100)
+	}
+
+	return &providers.Quote{
+		Symbol:                     symbol,
+		ShortName:                  strings.ToUpper(strings.SplitN(symbol, "-", 2)[0]),
+		RegularMarketPrice:         price,
+		RegularMarketPreviousClose: prevClose,
+		RegularMarketTime:          int(time.Now().Unix()),
+		MarketState:                "REGULAR",
+		Currency:                   strings.ToUpper(vs),
+	}, nil
+}
+
+// Chart fetches historical market data for a crypto pair.
+func (c *CoinGecko) Chart(symbol string, range_, interval string) (*providers.Chart, error) {
+	id, vs, err := splitSymbol(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/coins/%s/market_chart?vs_currency=%s&days=%s", baseURL, id, vs, days(range_))
+	resp, err := c.httpClient().Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		Prices [][2]float64 `json:"prices"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+
+	out := &providers.Chart{Symbol: symbol}
+	for _, p := range body.Prices {
+		out.Bars = append(out.Bars, providers.ChartBar{
+			Timestamp: int(p[0] / 1000),
+			Close:     p[1],
+		})
+	}
+
+	return out, nil
+}
+
+// days translates the common range vocabulary providers.Provider.Chart
+// callers use (Yahoo's "1mo", "3mo", "ytd", ...) into the plain day
+// count - or "max" - that CoinGecko's /market_chart "days" param wants.
+func days(range_ string) string {
+	switch range_ {
+	case "1d":
+		return "1"
+	case "5d":
+		return "5"
+	case "3mo":
+		return "90"
+	case "6mo":
+		return "180"
+	case "1y":
+		return "365"
+	case "2y":
+		return "730"
+	case "5y":
+		return "1825"
+	case "10y":
+		return "3650"
+	case "ytd":
+		return strconv.Itoa(int(time.Since(time.Date(time.Now().Year(), time.January, 1, 0, 0, 0, 0, time.UTC)).Hours()/24) + 1)
+	case "max":
+		return "max"
+	default: // "1mo" and anything unrecognized.
+		return "30"
+	}
+}
+
+// Search is unsupported; CoinGecko lookups are done via the coinIDs table.
+func (c *CoinGecko) Search(q string) ([]providers.Match, error) {
+	var out []providers.Match
+	for ticker := range coinIDs {
+		if strings.Contains(ticker, strings.ToUpper(q)) {
+			out = append(out, providers.Match{Symbol: ticker, Name: coinIDs[ticker], Type: "CRYPTOCURRENCY"})
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,70 @@
+// Copyright © 2018 Piquette Capital, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package providers
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+)
+
+// configPath is where the provider selection is persisted.
+const configPath = ".qtrn/config.toml"
+
+// Config is the contents of ~/.qtrn/config.toml.
+type Config struct {
+	Provider string `toml:"provider"`
+}
+
+// LoadConfig reads ~/.qtrn/config.toml, defaulting Provider to "yahoo"
+// when the file is missing or leaves it unset.
+func LoadConfig() (*Config, error) {
+	cfg := &Config{Provider: "yahoo"}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return cfg, nil
+	}
+
+	path := filepath.Join(home, configPath)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return cfg, nil
+	}
+
+	if _, err := toml.DecodeFile(path, cfg); err != nil {
+		return nil, err
+	}
+	if cfg.Provider == "" {
+		cfg.Provider = "yahoo"
+	}
+
+	return cfg, nil
+}
+
+// Select resolves the active provider, preferring an explicit
+// --provider flag value over the config file's provider key.
+func Select(flag string) (Provider, error) {
+	if flag != "" {
+		return Get(flag)
+	}
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return Get(cfg.Provider)
+}
@@ -0,0 +1,179 @@
+// Copyright © 2018 Piquette Capital, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package iex implements providers.Provider against IEX Cloud.
+package iex
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/piquette/qtrn/providers"
+)
+
+func init() {
+	providers.Register("iex", func() providers.Provider { return &IEX{} })
+}
+
+const baseURL = "https://cloud.iexapis.com/stable"
+
+// tokenEnv is the environment variable holding the IEX Cloud API token.
+const tokenEnv = "IEX_TOKEN"
+
+// IEX is the IEX Cloud backed provider.
+type IEX struct {
+	client *http.Client
+}
+
+func (x *IEX) httpClient() *http.Client {
+	if x.client == nil {
+		x.client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return x.client
+}
+
+func (x *IEX) get(path string, v interface{}) error {
+	token := os.Getenv(tokenEnv)
+	if token == "" {
+		return fmt.Errorf("iex: %s is not set", tokenEnv)
+	}
+
+	url := fmt.Sprintf("%s%s&token=%s", baseURL, path, token)
+	resp, err := x.httpClient().Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("iex: %s returned %s", path, resp.Status)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// Quote fetches a single quote from IEX Cloud.
+func (x *IEX) Quote(symbol string) (*providers.Quote, error) {
+	var q struct {
+		Symbol         string  `json:"symbol"`
+		CompanyName    string  `json:"companyName"`
+		LatestPrice    float64 `json:"latestPrice"`
+		PreviousClose  float64 `json:"previousClose"`
+		LatestUpdate   int64   `json:"latestUpdate"`
+		IsUSMarketOpen bool    `json:"isUSMarketOpen"`
+		Currency       string  `json:"currency"`
+	}
+
+	if err := x.get(fmt.Sprintf("/stock/%s/quote?", symbol), &q); err != nil {
+		return nil, err
+	}
+
+	state := "Closed"
+	if q.IsUSMarketOpen {
+		state = "REGULAR"
+	}
+
+	currency := q.Currency
+	if currency == "" {
+		currency = "USD"
+	}
+
+	return &providers.Quote{
+		Symbol:                     q.Symbol,
+		ShortName:                  q.CompanyName,
+		RegularMarketPrice:         q.LatestPrice,
+		RegularMarketPreviousClose: q.PreviousClose,
+		RegularMarketTime:          int(q.LatestUpdate / 1000),
+		MarketState:                state,
+		Currency:                   currency,
+	}, nil
+}
+
+// Chart fetches historical OHLC bars from IEX Cloud.
+func (x *IEX) Chart(symbol string, range_, interval string) (*providers.Chart, error) {
+	var bars []struct {
+		Date   string  `json:"date"`
+		Open   float64 `json:"open"`
+		High   float64 `json:"high"`
+		Low    float64 `json:"low"`
+		Close  float64 `json:"close"`
+		Volume int64   `json:"volume"`
+	}
+
+	if err := x.get(fmt.Sprintf("/stock/%s/chart/%s?", symbol, chartRange(range_)), &bars); err != nil {
+		return nil, err
+	}
+
+	out := &providers.Chart{Symbol: symbol}
+	for _, b := range bars {
+		t, err := time.Parse("2006-01-02", b.Date)
+		if err != nil {
+			continue
+		}
+		out.Bars = append(out.Bars, providers.ChartBar{
+			Timestamp: int(t.Unix()),
+			Open:      b.Open,
+			High:      b.High,
+			Low:       b.Low,
+			Close:     b.Close,
+			Volume:    b.Volume,
+		})
+	}
+
+	return out, nil
+}
+
+// chartRange translates the common range vocabulary providers.Provider
+// callers use (Yahoo's "1mo", "3mo", "ytd", ...) into the range tokens
+// IEX Cloud's /chart/<range> path segment expects ("1m", "3m", "ytd",
+// ...).
+func chartRange(range_ string) string {
+	switch range_ {
+	case "1d", "5d", "1y", "2y", "5y", "ytd", "max":
+		return range_
+	case "1mo":
+		return "1m"
+	case "3mo":
+		return "3m"
+	case "6mo":
+		return "6m"
+	case "10y":
+		return "max"
+	default:
+		return "1m"
+	}
+}
+
+// Search looks up symbols matching q via IEX Cloud's symbol search.
+func (x *IEX) Search(q string) ([]providers.Match, error) {
+	var matches []struct {
+		Symbol       string `json:"symbol"`
+		SecurityName string `json:"securityName"`
+		SecurityType string `json:"securityType"`
+	}
+
+	if err := x.get(fmt.Sprintf("/search/%s?", q), &matches); err != nil {
+		return nil, err
+	}
+
+	out := make([]providers.Match, 0, len(matches))
+	for _, m := range matches {
+		out = append(out, providers.Match{Symbol: m.Symbol, Name: m.SecurityName, Type: m.SecurityType})
+	}
+
+	return out, nil
+}
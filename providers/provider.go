@@ -0,0 +1,83 @@
+// Copyright © 2018 Piquette Capital, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package providers defines the quote provider interface shared by the
+// yahoo, coingecko, and iex backends, and the provider-agnostic types
+// they all return.
+package providers
+
+import "fmt"
+
+// Quote is a provider-agnostic price quote.
+type Quote struct {
+	Symbol                     string
+	ShortName                  string
+	RegularMarketPrice         float64
+	RegularMarketPreviousClose float64
+	RegularMarketTime          int
+	MarketState                string
+	Currency                   string
+}
+
+// ChartBar is a single OHLC bar in a Chart.
+type ChartBar struct {
+	Timestamp int
+	Open      float64
+	High      float64
+	Low       float64
+	Close     float64
+	Volume    int64
+}
+
+// Chart is a series of historical bars for a symbol.
+type Chart struct {
+	Symbol string
+	Bars   []ChartBar
+}
+
+// Match is a single symbol search result.
+type Match struct {
+	Symbol string
+	Name   string
+	Type   string
+}
+
+// Provider is a quote backend. Implementations wrap a specific upstream
+// API (Yahoo Finance, CoinGecko, IEX Cloud, ...).
+type Provider interface {
+	Quote(symbol string) (*Quote, error)
+	Chart(symbol string, range_, interval string) (*Chart, error)
+	Search(q string) ([]Match, error)
+}
+
+// registry holds the known provider constructors, keyed by name as used
+// in config.toml's `provider` key and the --provider flag.
+var registry = map[string]func() Provider{}
+
+// Register adds a named provider constructor. Backend packages call this
+// from an init() func so that importing them for side effects is enough
+// to make them selectable.
+func Register(name string, new func() Provider) {
+	registry[name] = new
+}
+
+// Get returns the provider registered under name, or an error if none
+// of the imported backends registered that name.
+func Get(name string) (Provider, error) {
+	new, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("providers: unknown provider %q", name)
+	}
+	return new(), nil
+}
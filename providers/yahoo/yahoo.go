@@ -0,0 +1,142 @@
+// Copyright © 2018 Piquette Capital, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package yahoo implements providers.Provider on top of finance-go, which
+// was qtrn's only backend prior to the provider interface existing.
+package yahoo
+
+import (
+	"time"
+
+	"github.com/piquette/finance-go/chart"
+	"github.com/piquette/finance-go/datetime"
+	"github.com/piquette/finance-go/equity"
+	"github.com/piquette/finance-go/quote"
+
+	"github.com/piquette/qtrn/providers"
+)
+
+func init() {
+	providers.Register("yahoo", func() providers.Provider { return &Yahoo{} })
+}
+
+// Yahoo is the Yahoo Finance backed provider.
+type Yahoo struct{}
+
+// Quote fetches a single quote from Yahoo Finance.
+func (y *Yahoo) Quote(symbol string) (*providers.Quote, error) {
+	q, err := quote.Get(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	return &providers.Quote{
+		Symbol:                     q.Symbol,
+		ShortName:                  q.ShortName,
+		RegularMarketPrice:         q.RegularMarketPrice,
+		RegularMarketPreviousClose: q.RegularMarketPreviousClose,
+		RegularMarketTime:          q.RegularMarketTime,
+		MarketState:                string(q.MarketState),
+		Currency:                   q.CurrencyID,
+	}, nil
+}
+
+// Chart fetches historical OHLC bars from Yahoo Finance. range_ and
+// interval are datetime.Interval values such as "1mo" and "1d".
+func (y *Yahoo) Chart(symbol string, range_, interval string) (*providers.Chart, error) {
+	start, end := rangeWindow(range_)
+
+	params := &chart.Params{
+		Symbol:   symbol,
+		Start:    start,
+		End:      end,
+		Interval: datetime.Interval(interval),
+	}
+
+	iter := chart.Get(params)
+
+	out := &providers.Chart{Symbol: symbol}
+	for iter.Next() {
+		b := iter.Bar()
+		open, _ := b.Open.Float64()
+		high, _ := b.High.Float64()
+		low, _ := b.Low.Float64()
+		close, _ := b.Close.Float64()
+		out.Bars = append(out.Bars, providers.ChartBar{
+			Timestamp: b.Timestamp,
+			Open:      open,
+			High:      high,
+			Low:       low,
+			Close:     close,
+			Volume:    int64(b.Volume),
+		})
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// rangeWindow converts a range token (the same vocabulary finance-go
+// uses for its chart intervals: "1d", "5d", "1mo", "3mo", "6mo", "1y",
+// "2y", "5y", "10y", "ytd", "max") into the Start/End bounds finance-go
+// actually requires on chart.Params, anchored to now.
+func rangeWindow(range_ string) (start, end *datetime.Datetime) {
+	now := time.Now()
+	end = datetime.New(&now)
+
+	var from time.Time
+	switch range_ {
+	case "1d":
+		from = now.AddDate(0, 0, -1)
+	case "5d":
+		from = now.AddDate(0, 0, -5)
+	case "3mo":
+		from = now.AddDate(0, -3, 0)
+	case "6mo":
+		from = now.AddDate(0, -6, 0)
+	case "1y":
+		from = now.AddDate(-1, 0, 0)
+	case "2y":
+		from = now.AddDate(-2, 0, 0)
+	case "5y":
+		from = now.AddDate(-5, 0, 0)
+	case "10y":
+		from = now.AddDate(-10, 0, 0)
+	case "ytd":
+		from = time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, now.Location())
+	case "max":
+		from = now.AddDate(-50, 0, 0)
+	default: // "1mo", "", and anything unrecognized.
+		from = now.AddDate(0, -1, 0)
+	}
+
+	return datetime.New(&from), end
+}
+
+// Search looks up a single symbol via Yahoo's equity quotes; finance-go
+// has no fuzzy lookup endpoint, so this only succeeds on an exact match.
+func (y *Yahoo) Search(q string) ([]providers.Match, error) {
+	e, err := equity.Get(q)
+	if err != nil {
+		return nil, err
+	}
+
+	return []providers.Match{{
+		Symbol: e.Symbol,
+		Name:   e.ShortName,
+		Type:   string(e.QuoteType),
+	}}, nil
+}
@@ -0,0 +1,305 @@
+// Copyright © 2018 Piquette Capital, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// localeSeparators holds the thousands and decimal separators for a
+// locale, as used by FormatNumber.
+type localeSeparators struct {
+	thousands string
+	decimal   string
+}
+
+// locales is the small set of locales FormatNumber understands. Unknown
+// locales fall back to "en-US".
+var locales = map[string]localeSeparators{
+	"en-US": {thousands: ",", decimal: "."},
+	"de-DE": {thousands: ".", decimal: ","},
+	"fr-FR": {thousands: " ", decimal: ","}, // narrow/regular NBSP
+}
+
+func localeFor(locale string) localeSeparators {
+	if l, ok := locales[locale]; ok {
+		return l
+	}
+	return locales["en-US"]
+}
+
+// FormatNumber renders value according to an Excel-style format code,
+// honoring locale-specific grouping and decimal separators. Supported
+// codes: plain grouped numbers ("#,##0.00"), currency prefixes/suffixes
+// ("$#,##0.00", "#,##0.00 €", "¥#,##0"), percentages ("0.00%"),
+// scientific notation ("0.00E+00"), fractions ("# ?/?", "# ??/??"), and
+// a second, semicolon-separated section for negatives, e.g.
+// "#,##0.00;(#,##0.00)".
+func FormatNumber(value float64, code string, locale string) string {
+	sep := localeFor(locale)
+
+	negative := value < 0 || math.Signbit(value)
+	abs := math.Abs(value)
+
+	sections := strings.SplitN(code, ";", 2)
+	active := sections[0]
+	parens := false
+
+	// A negative value that rounds away to zero at this code's precision
+	// (e.g. -0.001 at "#,##0.00") should print as a plain zero, not
+	// "-0.00" - drop the sign rather than pick the negative section.
+	if negative && renderSection(abs, sections[0], sep) == renderSection(0, sections[0], sep) {
+		negative = false
+	}
+
+	if negative {
+		if len(sections) == 2 {
+			active = sections[1]
+			if strings.HasPrefix(active, "(") && strings.HasSuffix(active, ")") {
+				parens = true
+				active = active[1 : len(active)-1]
+			}
+		}
+	}
+
+	out := renderSection(abs, active, sep)
+
+	if negative {
+		if parens {
+			return "(" + out + ")"
+		}
+		if len(sections) == 2 {
+			return out
+		}
+		return "-" + out
+	}
+	return out
+}
+
+// renderSection renders abs through a single (non-semicolon-sectioned)
+// format code section.
+func renderSection(abs float64, code string, sep localeSeparators) string {
+	switch {
+	case strings.Contains(code, "%"):
+		return formatPercent(abs, code, sep)
+	case strings.Contains(code, "E+") || strings.Contains(code, "E-"):
+		return formatScientific(abs, code)
+	case strings.ContainsAny(code, "?"):
+		return formatFraction(abs, code)
+	default:
+		prefix, suffix, decimals := parseDecorations(code)
+		return prefix + formatGrouped(abs, decimals, sep) + suffix
+	}
+}
+
+// parseDecorations splits a numeric format code into its literal
+// prefix/suffix (currency symbols, spaces) and the number of decimal
+// places requested by the "0" run after the decimal point.
+func parseDecorations(code string) (prefix, suffix string, decimals int) {
+	digits := "0123456789#,."
+	start := strings.IndexFunc(code, func(r rune) bool { return strings.ContainsRune(digits, r) })
+	if start == -1 {
+		return code, "", 0
+	}
+
+	end := start
+	for end < len(code) && strings.ContainsRune(digits, rune(code[end])) {
+		end++
+	}
+
+	prefix = code[:start]
+	suffix = code[end:]
+	numeric := code[start:end]
+
+	if i := strings.IndexByte(numeric, '.'); i != -1 {
+		decimals = len(numeric) - i - 1
+	}
+
+	return prefix, suffix, decimals
+}
+
+// formatGrouped renders abs with thousands grouping and decimals places
+// after the decimal point, using round-half-to-even on the final digit.
+func formatGrouped(abs float64, decimals int, sep localeSeparators) string {
+	rounded := roundHalfEven(abs, decimals)
+
+	s := strconv.FormatFloat(rounded, 'f', decimals, 64)
+
+	whole := s
+	frac := ""
+	if i := strings.IndexByte(s, '.'); i != -1 {
+		whole = s[:i]
+		frac = s[i+1:]
+	}
+
+	grouped := groupThousands(whole, sep.thousands)
+	if frac != "" {
+		return grouped + sep.decimal + frac
+	}
+	return grouped
+}
+
+// groupThousands inserts sep every three digits from the right.
+func groupThousands(whole, sep string) string {
+	if sep == "" || len(whole) <= 3 {
+		return whole
+	}
+
+	var parts []string
+	for len(whole) > 3 {
+		parts = append([]string{whole[len(whole)-3:]}, parts...)
+		whole = whole[:len(whole)-3]
+	}
+	parts = append([]string{whole}, parts...)
+
+	return strings.Join(parts, sep)
+}
+
+// roundHalfEven rounds v to the given number of decimal places using
+// banker's rounding, so 0.5 rounds to the nearest even digit.
+func roundHalfEven(v float64, decimals int) float64 {
+	scale := math.Pow(10, float64(decimals))
+	scaled := v * scale
+
+	floor := math.Floor(scaled)
+	diff := scaled - floor
+
+	switch {
+	case diff < 0.5:
+		scaled = floor
+	case diff > 0.5:
+		scaled = floor + 1
+	default:
+		if math.Mod(floor, 2) == 0 {
+			scaled = floor
+		} else {
+			scaled = floor + 1
+		}
+	}
+
+	return scaled / scale
+}
+
+// formatPercent renders abs as a percentage per code, e.g. "0.00%".
+func formatPercent(abs float64, code string, sep localeSeparators) string {
+	_, _, decimals := parseDecorations(strings.TrimSuffix(code, "%"))
+	return formatGrouped(abs*100, decimals, sep) + "%"
+}
+
+// formatScientific renders abs in scientific notation per code, e.g.
+// "0.00E+00".
+func formatScientific(abs float64, code string) string {
+	mantissaCode := code[:strings.IndexAny(code, "Ee")]
+	_, _, decimals := parseDecorations(mantissaCode)
+
+	s := strconv.FormatFloat(abs, 'E', decimals, 64)
+
+	// Go renders "1.23E+04"; Excel wants a two-digit exponent minimum,
+	// which strconv already provides, so s is used as-is.
+	return s
+}
+
+// formatFraction renders abs as an integer part plus the nearest
+// fraction whose denominator is bounded by the number of '?' in the
+// format code's denominator run (e.g. "??" bounds the denominator to 99),
+// using the continued-fraction (Stern-Brocot) method.
+func formatFraction(abs float64, code string) string {
+	parts := strings.SplitN(code, "/", 2)
+	maxDenom := 9
+	if len(parts) == 2 {
+		maxDenom = int(math.Pow10(strings.Count(parts[1], "?"))) - 1
+		if maxDenom < 1 {
+			maxDenom = 9
+		}
+	}
+
+	whole := math.Floor(abs)
+	frac := abs - whole
+
+	num, den := bestFraction(frac, maxDenom)
+	if den == 1 {
+		whole += float64(num)
+		num = 0
+	}
+
+	if num == 0 {
+		if whole == 0 {
+			return "0"
+		}
+		return strconv.FormatFloat(whole, 'f', 0, 64)
+	}
+
+	if whole == 0 {
+		return fmt.Sprintf("%d/%d", num, den)
+	}
+	return fmt.Sprintf("%s %d/%d", strconv.FormatFloat(whole, 'f', 0, 64), num, den)
+}
+
+// bestFraction finds the best rational approximation num/den of x with
+// den <= maxDenom, via the continued-fraction expansion: repeatedly take
+// a = floor(x), recurse on 1/(x-a), and stop once a further term would
+// push the denominator past maxDenom.
+func bestFraction(x float64, maxDenom int) (num, den int) {
+	if x == 0 {
+		return 0, 1
+	}
+
+	// Convergents h/k, tracked two terms back for the recurrence
+	// h_n = a_n*h_{n-1} + h_{n-2}.
+	h0, h1 := 0, 1
+	k0, k1 := 1, 0
+
+	frac := x
+	for i := 0; i < 64; i++ {
+		a := int(math.Floor(frac))
+
+		h2 := a*h1 + h0
+		k2 := a*k1 + k0
+
+		if k2 > maxDenom {
+			// The new full convergent overshoots maxDenom. The best
+			// bounded approximation is either the previous convergent
+			// h1/k1, or the semiconvergent at the largest a' <= a that
+			// keeps the denominator in bounds - whichever lands closer
+			// to x.
+			if k1 == 0 {
+				return h1, 1
+			}
+
+			aMax := (maxDenom - k0) / k1
+			semiNum := aMax*h1 + h0
+			semiDen := aMax*k1 + k0
+
+			if math.Abs(x-float64(semiNum)/float64(semiDen)) < math.Abs(x-float64(h1)/float64(k1)) {
+				return semiNum, semiDen
+			}
+			return h1, k1
+		}
+
+		h0, h1 = h1, h2
+		k0, k1 = k1, k2
+
+		remainder := frac - float64(a)
+		if remainder < 1e-9 {
+			break
+		}
+		frac = 1 / remainder
+	}
+
+	return h1, k1
+}
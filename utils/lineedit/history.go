@@ -0,0 +1,122 @@
+// Copyright © 2018 Piquette Capital, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lineedit
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+)
+
+// historyFile is where History persists entries between sessions.
+const historyFile = ".qtrn/history"
+
+// History is a ring buffer of previously entered lines, walked with
+// Prev/Next the way shell line editors do.
+type History struct {
+	lines []string
+	cap   int
+	pos   int
+}
+
+// NewHistory builds an empty History bounded to cap entries.
+func NewHistory(cap int) *History {
+	return &History{cap: cap}
+}
+
+// LoadHistory reads ~/.qtrn/history into a new History bounded to cap
+// entries, oldest-first. A missing file yields an empty History.
+func LoadHistory(cap int) (*History, error) {
+	h := NewHistory(cap)
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return h, nil
+	}
+
+	f, err := os.Open(filepath.Join(home, historyFile))
+	if os.IsNotExist(err) {
+		return h, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		h.Add(scanner.Text())
+	}
+
+	return h, scanner.Err()
+}
+
+// Save appends History to ~/.qtrn/history.
+func (h *History) Save() error {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(home, historyFile)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	for _, line := range h.lines {
+		if _, err := w.WriteString(line + "\n"); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+// Add appends a line to History, evicting the oldest entry once cap is
+// exceeded, and resets the walk position to just past the newest entry.
+func (h *History) Add(line string) {
+	h.lines = append(h.lines, line)
+	if len(h.lines) > h.cap {
+		h.lines = h.lines[len(h.lines)-h.cap:]
+	}
+	h.pos = len(h.lines)
+}
+
+// Prev walks one entry further into the past, returning false once
+// there's nothing older left.
+func (h *History) Prev() (string, bool) {
+	if h.pos == 0 {
+		return "", false
+	}
+	h.pos--
+	return h.lines[h.pos], true
+}
+
+// Next walks one entry back toward the present, returning false once
+// already at the newest entry.
+func (h *History) Next() (string, bool) {
+	if h.pos >= len(h.lines)-1 {
+		h.pos = len(h.lines)
+		return "", false
+	}
+	h.pos++
+	return h.lines[h.pos], true
+}
@@ -0,0 +1,76 @@
+// Copyright © 2018 Piquette Capital, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lineedit
+
+// Sentinel rune values for non-printable keys readKey can return. These
+// live outside the unicode range so they can't collide with a typed
+// rune.
+const (
+	keyEnter     rune = '\r'
+	keyBackspace rune = 0x7f
+	keyLeft      rune = -(iota + 1)
+	keyRight
+	keyUp
+	keyDown
+	keyHome
+	keyEnd
+)
+
+// readKey reads a single keystroke, decoding the `ESC [ ...` cursor-key
+// escape sequences emitted by most terminals in raw mode.
+func (e *Editor) readKey() (rune, error) {
+	r, _, err := e.r.ReadRune()
+	if err != nil {
+		return 0, err
+	}
+
+	if r != 0x1b {
+		if r == '\n' {
+			return keyEnter, nil
+		}
+		if r == 0x08 {
+			return keyBackspace, nil
+		}
+		return r, nil
+	}
+
+	// ESC [ <code> for arrow/home/end keys.
+	second, _, err := e.r.ReadRune()
+	if err != nil || second != '[' {
+		return 0, err
+	}
+
+	third, _, err := e.r.ReadRune()
+	if err != nil {
+		return 0, err
+	}
+
+	switch third {
+	case 'A':
+		return keyUp, nil
+	case 'B':
+		return keyDown, nil
+	case 'C':
+		return keyRight, nil
+	case 'D':
+		return keyLeft, nil
+	case 'H':
+		return keyHome, nil
+	case 'F':
+		return keyEnd, nil
+	}
+
+	return e.readKey()
+}
@@ -0,0 +1,121 @@
+// Copyright © 2018 Piquette Capital, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lineedit is a minimal single-line editor for raw-mode
+// terminals, built for the `interactive` command's symbol/command
+// buffer: left/right/backspace/home/end and up/down history.
+package lineedit
+
+import (
+	"bufio"
+	"io"
+
+	"github.com/piquette/qtrn/utils"
+)
+
+// Editor reads a single line from r, rendering it to w with basic
+// cursor-movement editing. The caller is expected to have already put
+// the terminal backing r into raw mode (see golang.org/x/term.MakeRaw).
+type Editor struct {
+	r       *bufio.Reader
+	w       io.Writer
+	History *History
+}
+
+// NewEditor builds an Editor that reads raw keystrokes from r and
+// echoes/redraws to w.
+func NewEditor(r io.Reader, w io.Writer) *Editor {
+	return &Editor{r: bufio.NewReader(r), w: w, History: NewHistory(256)}
+}
+
+// Prompt writes prefix, then reads and returns a line, honoring
+// left/right/backspace/home/end for in-line editing and up/down to walk
+// History. It returns on Enter, or an error if the underlying reader
+// fails (including io.EOF on Ctrl-D).
+func (e *Editor) Prompt(prefix string) (string, error) {
+	buf := []rune{}
+	pos := 0
+
+	redraw := func() {
+		io.WriteString(e.w, utils.ESC+"[2K\r")
+		io.WriteString(e.w, prefix+string(buf))
+		if back := len(buf) - pos; back > 0 {
+			io.WriteString(e.w, utils.ESC+"["+itoa(back)+"D")
+		}
+	}
+	redraw()
+
+	for {
+		key, err := e.readKey()
+		if err != nil {
+			return "", err
+		}
+
+		switch key {
+		case keyEnter:
+			io.WriteString(e.w, "\r\n")
+			line := string(buf)
+			if line != "" {
+				e.History.Add(line)
+			}
+			return line, nil
+		case keyBackspace:
+			if pos > 0 {
+				buf = append(buf[:pos-1], buf[pos:]...)
+				pos--
+			}
+		case keyLeft:
+			if pos > 0 {
+				pos--
+			}
+		case keyRight:
+			if pos < len(buf) {
+				pos++
+			}
+		case keyHome:
+			pos = 0
+		case keyEnd:
+			pos = len(buf)
+		case keyUp:
+			if line, ok := e.History.Prev(); ok {
+				buf = []rune(line)
+				pos = len(buf)
+			}
+		case keyDown:
+			if line, ok := e.History.Next(); ok {
+				buf = []rune(line)
+			} else {
+				buf = nil
+			}
+			pos = len(buf)
+		default:
+			buf = append(buf[:pos], append([]rune{key}, buf[pos:]...)...)
+			pos++
+		}
+
+		redraw()
+	}
+}
+
+func itoa(n int) string {
+	if n == 0 {
+		return "0"
+	}
+	var digits []byte
+	for n > 0 {
+		digits = append([]byte{byte('0' + n%10)}, digits...)
+		n /= 10
+	}
+	return string(digits)
+}
@@ -0,0 +1,122 @@
+// Copyright © 2018 Piquette Capital, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	finance "github.com/piquette/finance-go"
+)
+
+// templateDir is where a user may drop custom *.tmpl overrides.
+const templateDir = ".qtrn/templates"
+
+// defaultQuoteTmpl reproduces the built-in quote output.
+const defaultQuoteTmpl = `{{.Symbol}}  {{color (price .RegularMarketPrice) (direction .RegularMarketPrice .RegularMarketPreviousClose)}}  ({{mktState .MarketState}} as of {{relativeTime .RegularMarketTime}})
+`
+
+// defaultWatchlistTmpl reproduces the built-in watchlist output.
+const defaultWatchlistTmpl = `{{range .}}{{bold .Symbol}}	{{color (price .RegularMarketPrice) (direction .RegularMarketPrice .RegularMarketPreviousClose)}}
+{{end}}`
+
+// defaultOptionsTmpl reproduces the built-in options chain output.
+const defaultOptionsTmpl = `{{range .}}{{.ContractSymbol}}	strike {{price .Strike}}	last {{price .LastPrice}}
+{{end}}`
+
+// Renderer renders quote, watchlist, and options output through a
+// text/template so users can customize it without recompiling.
+type Renderer struct {
+	tmpl *template.Template
+}
+
+// funcMap exposes the existing utils formatting helpers as template funcs.
+func funcMap() template.FuncMap {
+	return template.FuncMap{
+		"color": func(s string, d int) string {
+			return Color(s, Direction(d))
+		},
+		"bold": Bold,
+		"direction": func(last, close float64) int {
+			if last > close {
+				return int(Up)
+			}
+			if last < close {
+				return int(Down)
+			}
+			return int(Flat)
+		},
+		"mktState": func(m string) string {
+			return MktStateF(finance.MarketState(m))
+		},
+		"numFancy": func(n float64) string {
+			return NumberFancyF(int64(n))
+		},
+		"price": func(n float64) string {
+			return FormatNumber(n, "#,##0.00", "en-US")
+		},
+		"dateShort": func(ts int) string {
+			return DateFS(ts)
+		},
+		"relativeTime": func(ts int) string {
+			return RelativeTime(ts)
+		},
+	}
+}
+
+// NewRenderer builds a Renderer for the named template ("quote", "watchlist",
+// or "options"). A template override at path, or failing that
+// ~/.qtrn/templates/<name>.tmpl, takes precedence over the built-in default.
+func NewRenderer(name, path string) (*Renderer, error) {
+	defaults := map[string]string{
+		"quote":     defaultQuoteTmpl,
+		"watchlist": defaultWatchlistTmpl,
+		"options":   defaultOptionsTmpl,
+	}
+
+	def, ok := defaults[name]
+	if !ok {
+		return nil, fmt.Errorf("render: unknown template %q", name)
+	}
+
+	src := def
+	if path != "" {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		src = string(b)
+	} else if home, err := os.UserHomeDir(); err == nil {
+		override := filepath.Join(home, templateDir, name+".tmpl")
+		if b, err := os.ReadFile(override); err == nil {
+			src = string(b)
+		}
+	}
+
+	t, err := template.New(name).Funcs(funcMap()).Parse(src)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Renderer{tmpl: t}, nil
+}
+
+// Render executes the template against data, writing the result to w.
+func (r *Renderer) Render(w io.Writer, data interface{}) error {
+	return r.tmpl.Execute(w, data)
+}
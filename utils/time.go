@@ -0,0 +1,101 @@
+// Copyright © 2018 Piquette Capital, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"fmt"
+	"time"
+)
+
+// RelativeTime renders a Unix timestamp relative to now, e.g. "just
+// now", "5 seconds ago", "3 minutes ago", "2 hours ago", "yesterday",
+// "3 days ago", "last week", or "in 5 minutes" for a future timestamp.
+// Beyond about a month it falls back to DateFS.
+func RelativeTime(ts int) string {
+	if ts == 0 {
+		return "--"
+	}
+	return RelativeTimeSince(time.Unix(int64(ts), 0))
+}
+
+// RelativeTimeSince is the time.Time variant of RelativeTime.
+func RelativeTimeSince(t time.Time) string {
+	d := time.Since(t)
+
+	future := d < 0
+	if future {
+		d = -d
+	}
+
+	n, unit, standalone, ok := relativeBucket(d, future)
+	if !ok {
+		return DateFS(int(t.Unix()))
+	}
+	if standalone {
+		return unit
+	}
+
+	phrase := plural(n, unit)
+	if future {
+		return "in " + phrase
+	}
+	return phrase + " ago"
+}
+
+// relativeBucket places d into a threshold bucket. For quantified
+// buckets ("3 minutes") it returns n and the singular unit name. For
+// fixed phrases ("just now", "yesterday"/"tomorrow", "last week"/"next
+// week") it returns the full phrase as unit with standalone set, chosen
+// according to future. ok is false once d is too large for a relative
+// phrase to be useful (beyond ~1 month).
+func relativeBucket(d time.Duration, future bool) (n int, unit string, standalone bool, ok bool) {
+	switch {
+	case d < 5*time.Second:
+		return 0, "just now", true, true
+	case d < time.Minute:
+		return int(d / time.Second), "second", false, true
+	case d < time.Hour:
+		return int(d / time.Minute), "minute", false, true
+	case d < 24*time.Hour:
+		return int(d / time.Hour), "hour", false, true
+	case d < 48*time.Hour:
+		if future {
+			return 0, "tomorrow", true, true
+		}
+		return 0, "yesterday", true, true
+	case d < 7*24*time.Hour:
+		return int(d / (24 * time.Hour)), "day", false, true
+	case d < 30*24*time.Hour:
+		weeks := int(d / (7 * 24 * time.Hour))
+		if weeks <= 1 {
+			if future {
+				return 0, "next week", true, true
+			}
+			return 0, "last week", true, true
+		}
+		return weeks, "week", false, true
+	default:
+		return 0, "", false, false
+	}
+}
+
+// plural renders "n unit" or "n units", dropping the trailing "s" when
+// n == 1 ("1 hour ago", not "1 hours ago").
+func plural(n int, unit string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, unit)
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
@@ -24,6 +24,8 @@ import (
 
 	humanize "github.com/dustin/go-humanize"
 	finance "github.com/piquette/finance-go"
+
+	"github.com/piquette/qtrn/providers"
 )
 
 // Direction is a price direction.
@@ -39,7 +41,7 @@ const (
 const ESC = "\033"
 
 // PriceDirection returns a plus/minus indicating price direction.
-func PriceDirection(q *finance.Quote) Direction {
+func PriceDirection(q *providers.Quote) Direction {
 
 	last := q.RegularMarketPrice
 	close := q.RegularMarketPreviousClose